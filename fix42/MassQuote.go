@@ -1,8 +1,13 @@
 package fix42
 
 import (
+	"bytes"
+	"fmt"
+	"strconv"
+
 	"github.com/cbusbey/quickfixgo"
 	"github.com/cbusbey/quickfixgo/field"
+	"github.com/cbusbey/quickfixgo/tag"
 )
 
 type MassQuote struct {
@@ -33,4 +38,225 @@ func (m *MassQuote) DefOfferSize() (*field.DefOfferSize, error) {
 	f := new(field.DefOfferSize)
 	err := m.Body.Get(f)
 	return f, err
-}
\ No newline at end of file
+}
+
+//NoQuoteSets decodes the NoQuoteSets/NoQuoteEntries repeating group out of raw, the raw
+//FIX bytes of this message's body starting at the group's first QuoteSetID field (the
+//fields before it, NoQuoteSets included, must already be skipped). quickfixgo.Message - generated against
+//github.com/cbusbey/quickfixgo, a different module than this repo's own
+//github.com/quickfixgo/quickfix and its fieldMap - has no GetGroup/AddGroup primitive
+//(and no documented way to read its own body's raw bytes back out), so unlike the scalar
+//accessors above this can't be a zero-argument method on m; callers pass in the raw body
+//bytes they already have from reading the message. See DecodeQuoteSets for the real,
+//independently-tested parsing logic this delegates to.
+func (m *MassQuote) NoQuoteSets(raw []byte) ([]QuoteSet, error) {
+	noQuoteSets := new(field.NoQuoteSets)
+	if err := m.Body.Get(noQuoteSets); err != nil {
+		return nil, err
+	}
+
+	return DecodeQuoteSets(raw, noQuoteSets.Value)
+}
+
+//QuoteSet is a single entry of the MassQuote NoQuoteSets repeating group.
+type QuoteSet struct {
+	QuoteSetID        string
+	UnderlyingSymbol  string
+	TotNoQuoteEntries string
+	QuoteEntries      []QuoteEntry
+}
+
+//QuoteEntry is a single entry of a QuoteSet's nested NoQuoteEntries repeating group.
+type QuoteEntry struct {
+	QuoteEntryID   string
+	Symbol         string
+	BidPx          string
+	OfferPx        string
+	BidSize        string
+	OfferSize      string
+	ValidUntilTime string
+}
+
+//NewMassQuote serializes a MassQuote - QuoteReqID, QuoteID, and its NoQuoteSets group -
+//as raw FIX body bytes, in NumInGroup/delimiter-tag order. It returns raw bytes rather
+//than a quickfixgo.MessageBuilder for the same reason NoQuoteSets above takes raw bytes
+//instead of reading them off m: the MessageBuilder this chunk's core quickfix package
+//exposes belongs to a different module than quickfixgo.Message is generated against.
+func NewMassQuote(quoteReqID, quoteID string, quoteSets []QuoteSet) []byte {
+	var buf bytes.Buffer
+	writeField(&buf, tag.QuoteReqID, quoteReqID)
+	writeField(&buf, tag.QuoteID, quoteID)
+	writeField(&buf, tag.NoQuoteSets, strconv.Itoa(len(quoteSets)))
+	buf.Write(EncodeQuoteSets(quoteSets))
+
+	return buf.Bytes()
+}
+
+//DecodeQuoteSets parses raw - which must begin with the first QuoteSet's QuoteSetID
+//field - into count QuoteSet entries, each with its own nested QuoteEntries decoded from
+//its NoQuoteEntries count; TotNoQuoteEntries is kept as read and not used as a count.
+func DecodeQuoteSets(raw []byte, count int) ([]QuoteSet, error) {
+	quoteSets := make([]QuoteSet, 0, count)
+
+	for i := 0; i < count; i++ {
+		quoteSetIDTag, quoteSetID, rest, err := readField(raw)
+		if err != nil {
+			return nil, fmt.Errorf("fix42: QuoteSet %d: %w", i, err)
+		}
+		if quoteSetIDTag != tag.QuoteSetID {
+			return nil, fmt.Errorf("fix42: QuoteSet %d: expected QuoteSetID(%d), got tag %d", i, tag.QuoteSetID, quoteSetIDTag)
+		}
+		raw = rest
+
+		quoteSet := QuoteSet{QuoteSetID: quoteSetID}
+
+		for {
+			if len(raw) == 0 {
+				goto doneQuoteSet
+			}
+			fieldTag, value, rest, err := readField(raw)
+			if err != nil {
+				return nil, fmt.Errorf("fix42: QuoteSet %d: %w", i, err)
+			}
+
+			switch fieldTag {
+			case tag.UnderlyingSymbol:
+				quoteSet.UnderlyingSymbol = value
+				raw = rest
+			case tag.TotNoQuoteEntries:
+				quoteSet.TotNoQuoteEntries = value
+				raw = rest
+			case tag.NoQuoteEntries:
+				raw = rest
+				entryCount, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, fmt.Errorf("fix42: QuoteSet %d: malformed NoQuoteEntries %q: %w", i, value, err)
+				}
+
+				entries, rest, err := decodeQuoteEntries(raw, entryCount)
+				if err != nil {
+					return nil, fmt.Errorf("fix42: QuoteSet %d: %w", i, err)
+				}
+				quoteSet.QuoteEntries = entries
+				raw = rest
+			default:
+				// next QuoteSet, or end of input; don't consume it.
+				goto doneQuoteSet
+			}
+		}
+	doneQuoteSet:
+
+		quoteSets = append(quoteSets, quoteSet)
+	}
+
+	return quoteSets, nil
+}
+
+//decodeQuoteEntries parses count QuoteEntry entries off the front of raw, returning the
+//remaining bytes after the last entry.
+func decodeQuoteEntries(raw []byte, count int) ([]QuoteEntry, []byte, error) {
+	entries := make([]QuoteEntry, 0, count)
+
+	for i := 0; i < count; i++ {
+		entryIDTag, entryID, rest, err := readField(raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("QuoteEntry %d: %w", i, err)
+		}
+		if entryIDTag != tag.QuoteEntryID {
+			return nil, nil, fmt.Errorf("QuoteEntry %d: expected QuoteEntryID(%d), got tag %d", i, tag.QuoteEntryID, entryIDTag)
+		}
+		raw = rest
+
+		entry := QuoteEntry{QuoteEntryID: entryID}
+
+		for {
+			if len(raw) == 0 {
+				goto doneEntry
+			}
+			fieldTag, value, rest, err := readField(raw)
+			if err != nil {
+				return nil, nil, fmt.Errorf("QuoteEntry %d: %w", i, err)
+			}
+
+			switch fieldTag {
+			case tag.Symbol:
+				entry.Symbol = value
+			case tag.BidPx:
+				entry.BidPx = value
+			case tag.OfferPx:
+				entry.OfferPx = value
+			case tag.BidSize:
+				entry.BidSize = value
+			case tag.OfferSize:
+				entry.OfferSize = value
+			case tag.ValidUntilTime:
+				entry.ValidUntilTime = value
+			default:
+				// next QuoteEntry/QuoteSet, or end of input; don't consume it.
+				goto doneEntry
+			}
+			raw = rest
+		}
+	doneEntry:
+
+		entries = append(entries, entry)
+	}
+
+	return entries, raw, nil
+}
+
+//EncodeQuoteSets serializes quoteSets back into NoQuoteSets/NoQuoteEntries raw FIX
+//bytes, in NumInGroup/delimiter-tag order; it is the exact inverse of DecodeQuoteSets.
+func EncodeQuoteSets(quoteSets []QuoteSet) []byte {
+	var buf bytes.Buffer
+
+	for _, quoteSet := range quoteSets {
+		writeField(&buf, tag.QuoteSetID, quoteSet.QuoteSetID)
+		if quoteSet.UnderlyingSymbol != "" {
+			writeField(&buf, tag.UnderlyingSymbol, quoteSet.UnderlyingSymbol)
+		}
+		if quoteSet.TotNoQuoteEntries != "" {
+			writeField(&buf, tag.TotNoQuoteEntries, quoteSet.TotNoQuoteEntries)
+		}
+		writeField(&buf, tag.NoQuoteEntries, strconv.Itoa(len(quoteSet.QuoteEntries)))
+
+		for _, entry := range quoteSet.QuoteEntries {
+			writeField(&buf, tag.QuoteEntryID, entry.QuoteEntryID)
+			writeField(&buf, tag.Symbol, entry.Symbol)
+			writeField(&buf, tag.BidPx, entry.BidPx)
+			writeField(&buf, tag.OfferPx, entry.OfferPx)
+			writeField(&buf, tag.BidSize, entry.BidSize)
+			writeField(&buf, tag.OfferSize, entry.OfferSize)
+			writeField(&buf, tag.ValidUntilTime, entry.ValidUntilTime)
+		}
+	}
+
+	return buf.Bytes()
+}
+
+//writeField appends a single SOH-delimited "tag=value" field to buf.
+func writeField(buf *bytes.Buffer, fieldTag int, value string) {
+	fmt.Fprintf(buf, "%d=%s\x01", fieldTag, value)
+}
+
+//readField reads the next SOH-delimited "tag=value" field off the front of raw,
+//returning its tag, value, and the remaining bytes after it.
+func readField(raw []byte) (fieldTag int, value string, rest []byte, err error) {
+	end := bytes.IndexByte(raw, '\x01')
+	if end == -1 {
+		return 0, "", nil, fmt.Errorf("no trailing SOH in %q", raw)
+	}
+
+	pair := raw[:end]
+	eq := bytes.IndexByte(pair, '=')
+	if eq == -1 {
+		return 0, "", nil, fmt.Errorf("malformed field %q", pair)
+	}
+
+	fieldTag, err = strconv.Atoi(string(pair[:eq]))
+	if err != nil {
+		return 0, "", nil, fmt.Errorf("malformed tag in field %q: %w", pair, err)
+	}
+
+	return fieldTag, string(pair[eq+1:]), raw[end+1:], nil
+}
@@ -0,0 +1,91 @@
+package fix42
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestQuoteSetsRoundTripThroughEncodeDecode(t *testing.T) {
+	quoteSets := []QuoteSet{
+		{
+			QuoteSetID:       "QS1",
+			UnderlyingSymbol: "IBM",
+			QuoteEntries: []QuoteEntry{
+				{
+					QuoteEntryID:   "QE1",
+					Symbol:         "IBM",
+					BidPx:          "100.5",
+					OfferPx:        "100.75",
+					BidSize:        "1000",
+					OfferSize:      "2000",
+					ValidUntilTime: "20260101-00:00:00",
+				},
+				{
+					QuoteEntryID: "QE2",
+					Symbol:       "IBM",
+					BidPx:        "100.4",
+					OfferPx:      "100.8",
+					BidSize:      "500",
+					OfferSize:    "500",
+				},
+			},
+		},
+		{
+			QuoteSetID: "QS2",
+			QuoteEntries: []QuoteEntry{
+				{QuoteEntryID: "QE3", Symbol: "GOOG", BidPx: "50", OfferPx: "51", BidSize: "10", OfferSize: "10"},
+			},
+		},
+	}
+
+	raw := EncodeQuoteSets(quoteSets)
+
+	decoded, err := DecodeQuoteSets(raw, len(quoteSets))
+	if err != nil {
+		t.Fatalf("DecodeQuoteSets: %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded, quoteSets) {
+		t.Fatalf("round trip mismatch:\n got:  %+v\n want: %+v", decoded, quoteSets)
+	}
+}
+
+func TestNewMassQuoteRoundTripsThroughNoQuoteSets(t *testing.T) {
+	quoteSets := []QuoteSet{
+		{
+			QuoteSetID: "QS1",
+			QuoteEntries: []QuoteEntry{
+				{QuoteEntryID: "QE1", Symbol: "IBM", BidPx: "100", OfferPx: "101", BidSize: "1", OfferSize: "1"},
+			},
+		},
+	}
+
+	raw := NewMassQuote("req-1", "quote-1", quoteSets)
+
+	// Skip over QuoteReqID, QuoteID and NoQuoteSets to reach the start of the group,
+	// mirroring what MassQuote.NoQuoteSets expects to be handed.
+	groupStart := raw
+	for i := 0; i < 3; i++ {
+		idx := bytes.IndexByte(groupStart, '\x01')
+		groupStart = groupStart[idx+1:]
+	}
+
+	decoded, err := DecodeQuoteSets(groupStart, len(quoteSets))
+	if err != nil {
+		t.Fatalf("DecodeQuoteSets: %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded, quoteSets) {
+		t.Fatalf("round trip mismatch:\n got:  %+v\n want: %+v", decoded, quoteSets)
+	}
+}
+
+func TestDecodeQuoteSetsRejectsOutOfOrderFields(t *testing.T) {
+	// UnderlyingSymbol(311) before QuoteSetID(302) - not a valid group start.
+	raw := []byte("311=IBM\x01")
+
+	if _, err := DecodeQuoteSets(raw, 1); err == nil {
+		t.Fatal("expected an error for a group not starting with QuoteSetID")
+	}
+}
@@ -0,0 +1,284 @@
+//Package grpcgw runs a gRPC server that exposes configured quickfix Sessions as
+//bidirectional streams, so non-Go processes can participate in a FIX session without
+//linking this library. The wire schema is generated from api/quickfix/v1/quickfix.proto
+//into the quickfixv1 package. See MessageEnvelope's doc comment there for what its
+//flattened header/body/trailer field maps can and can't represent - notably, a message
+//with a repeating group isn't faithfully representable in them at all.
+package grpcgw
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	quickfixv1 "github.com/quickfixgo/quickfix/api/quickfix/v1"
+	"github.com/quickfixgo/quickfix/fix"
+	"github.com/quickfixgo/quickfix/fix/tag"
+)
+
+//Session is the subset of session behavior the gateway needs. This chunk of the
+//repository does not include the Session type itself, so the gateway depends on this
+//narrow interface rather than the concrete type.
+type Session interface {
+	ID() string
+	Status() string
+	BeginString() string
+	Send(raw []byte) error
+	MessagesFrom(seqNum int) ([]EnvelopeSource, error)
+
+	//Subscribe returns a channel of messages the session receives from here on, and a
+	//cancel func to stop delivery and release it. The channel is closed once cancel has
+	//been called and any in-flight delivery has drained.
+	Subscribe() (inbound <-chan EnvelopeSource, cancel func())
+}
+
+//EnvelopeSource is anything a MessageEnvelope can be built from; quickfix.Message
+//satisfies it via its BeginString/MsgType/SendingTime/String methods.
+type EnvelopeSource interface {
+	String() string
+	BeginString() string
+	MsgType() string
+	SendingTime() string
+}
+
+//toEnvelope builds the MessageEnvelope wire representation of msg. header_fields/
+//body_fields/trailer_fields are left unset when msg's raw bytes can't be split into
+//fields - e.g. a message with a malformed trailing field - since Bytes is still present
+//for the consumer to fall back to.
+func toEnvelope(msg EnvelopeSource) *quickfixv1.MessageEnvelope {
+	env := &quickfixv1.MessageEnvelope{
+		BeginString: msg.BeginString(),
+		MsgType:     msg.MsgType(),
+		SendingTime: msg.SendingTime(),
+		Bytes:       []byte(msg.String()),
+	}
+
+	if header, body, trailer, err := splitFIXFields(env.Bytes); err == nil {
+		env.HeaderFields = header
+		env.BodyFields = body
+		env.TrailerFields = trailer
+	}
+
+	return env
+}
+
+//splitFIXFields partitions raw's SOH-delimited tag=value fields into header, body, and
+//trailer maps by tag.IsHeader/tag.IsTrailer, the same partitioning ParseInto does
+//internally. A tag that repeats - as every field inside a repeating group does - only
+//survives as its last occurrence once flattened into a map this way, so the result is
+//only faithful for a message with no repeating groups; see MessageEnvelope's doc comment
+//in quickfix.proto for that gap.
+func splitFIXFields(raw []byte) (header, body, trailer map[uint32][]byte, err error) {
+	header = map[uint32][]byte{}
+	body = map[uint32][]byte{}
+	trailer = map[uint32][]byte{}
+
+	for len(raw) > 0 {
+		end := bytes.IndexByte(raw, '\x01')
+		if end == -1 {
+			return nil, nil, nil, fmt.Errorf("grpcgw: no trailing SOH in %q", raw)
+		}
+
+		pair := raw[:end]
+		raw = raw[end+1:]
+
+		eq := bytes.IndexByte(pair, '=')
+		if eq == -1 {
+			return nil, nil, nil, fmt.Errorf("grpcgw: malformed field %q", pair)
+		}
+
+		tagNum, err := strconv.Atoi(string(pair[:eq]))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("grpcgw: malformed tag in field %q: %w", pair, err)
+		}
+		value := append([]byte(nil), pair[eq+1:]...)
+
+		switch {
+		case tag.IsHeader(fix.Tag(tagNum)):
+			header[uint32(tagNum)] = value
+		case tag.IsTrailer(fix.Tag(tagNum)):
+			trailer[uint32(tagNum)] = value
+		default:
+			body[uint32(tagNum)] = value
+		}
+	}
+
+	return header, body, trailer, nil
+}
+
+//SessionRegistry looks up the Sessions a Server is configured to expose.
+type SessionRegistry interface {
+	Session(sessionID string) (Session, bool)
+	SessionIDs() []string
+}
+
+//Server implements quickfixv1.QuickFixGatewayServer.
+type Server struct {
+	quickfixv1.UnimplementedQuickFixGatewayServer
+	sessions SessionRegistry
+}
+
+//NewServer returns a Server exposing the sessions in the given registry.
+func NewServer(sessions SessionRegistry) *Server {
+	return &Server{sessions: sessions}
+}
+
+func (s *Server) session(sessionID string) (Session, error) {
+	session, ok := s.sessions.Session(sessionID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no session %q", sessionID)
+	}
+	return session, nil
+}
+
+//Attach streams decoded inbound messages for a session and accepts outbound messages
+//pushed back through the same stream. The first message received must be an
+//AttachRequest naming the session.
+func (s *Server) Attach(stream quickfixv1.QuickFixGateway_AttachServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	attach := first.GetAttach()
+	if attach == nil {
+		return status.Error(codes.InvalidArgument, "first message on Attach must be an AttachRequest")
+	}
+
+	session, err := s.session(attach.SessionId)
+	if err != nil {
+		return err
+	}
+
+	inbound, cancel := session.Subscribe()
+	defer cancel()
+
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			in, err := stream.Recv()
+			if err == io.EOF {
+				recvErr <- nil
+				return
+			}
+			if err != nil {
+				recvErr <- err
+				return
+			}
+
+			if out := in.GetOutbound(); out != nil {
+				if err := session.Send(out.GetBytes()); err != nil {
+					recvErr <- status.Error(codes.Internal, err.Error())
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		select {
+		case err := <-recvErr:
+			return err
+		case msg, ok := <-inbound:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(toEnvelope(msg)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+//Send pushes a single message to the named session.
+func (s *Server) Send(ctx context.Context, req *quickfixv1.SendRequest) (*quickfixv1.SendResponse, error) {
+	session, err := s.session(req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := req.GetMessage()
+	if msg == nil {
+		return nil, status.Error(codes.InvalidArgument, "message is required")
+	}
+
+	if err := session.Send(msg.GetBytes()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &quickfixv1.SendResponse{}, nil
+}
+
+//ListSessions returns the IDs of every session this gateway exposes.
+func (s *Server) ListSessions(ctx context.Context, _ *quickfixv1.ListSessionsRequest) (*quickfixv1.ListSessionsResponse, error) {
+	return &quickfixv1.ListSessionsResponse{SessionIds: s.sessions.SessionIDs()}, nil
+}
+
+//GetSessionStatus reports the current status of the named session.
+func (s *Server) GetSessionStatus(ctx context.Context, req *quickfixv1.GetSessionStatusRequest) (*quickfixv1.GetSessionStatusResponse, error) {
+	session, err := s.session(req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &quickfixv1.GetSessionStatusResponse{Status: session.Status()}, nil
+}
+
+//ResendRequest builds and sends a well-formed FIX ResendRequest(2) for the given
+//sequence range.
+func (s *Server) ResendRequest(ctx context.Context, req *quickfixv1.ResendRequestRequest) (*quickfixv1.ResendRequestResponse, error) {
+	session, err := s.session(req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := newResendRequestBytes(session.BeginString(), req.BeginSeqNo, req.EndSeqNo)
+	if err := session.Send(raw); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &quickfixv1.ResendRequestResponse{}, nil
+}
+
+//Tail replays the message store for a session from a given MsgSeqNo.
+func (s *Server) Tail(req *quickfixv1.TailRequest, stream quickfixv1.QuickFixGateway_TailServer) error {
+	session, err := s.session(req.SessionId)
+	if err != nil {
+		return err
+	}
+
+	messages, err := session.MessagesFrom(int(req.FromSeqNum))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	for _, msg := range messages {
+		if err := stream.Send(toEnvelope(msg)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//newResendRequestBytes serializes a well-formed FIX ResendRequest(2): BeginString,
+//BodyLength, MsgType, the BeginSeqNo/EndSeqNo range, and a trailing CheckSum. The
+//MessageBuilder this chunk's core quickfix package exposes isn't reachable from here, so
+//the gateway computes the framing itself.
+func newResendRequestBytes(beginString string, beginSeqNo, endSeqNo int32) []byte {
+	body := fmt.Sprintf("%d=2\x01%d=%d\x01%d=%d\x01", tag.MsgType, tag.BeginSeqNo, beginSeqNo, tag.EndSeqNo, endSeqNo)
+	header := fmt.Sprintf("%d=%s\x01%d=%d\x01", tag.BeginString, beginString, tag.BodyLength, len(body))
+
+	checkSum := 0
+	for _, b := range []byte(header + body) {
+		checkSum += int(b)
+	}
+	checkSum %= 256
+
+	return []byte(fmt.Sprintf("%s%s%d=%03d\x01", header, body, tag.CheckSum, checkSum))
+}
@@ -0,0 +1,77 @@
+package grpcgw
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/quickfixgo/quickfix/fix/tag"
+)
+
+func TestNewResendRequestBytesIsWellFormed(t *testing.T) {
+	raw := string(newResendRequestBytes("FIX.4.2", 1, 10))
+
+	if !strings.HasPrefix(raw, "8=FIX.4.2\x01") {
+		t.Fatalf("expected message to start with BeginString, got %q", raw)
+	}
+	if !strings.Contains(raw, "\x0135=2\x01") {
+		t.Fatalf("expected a MsgType=2 (ResendRequest) field, got %q", raw)
+	}
+	if !strings.Contains(raw, "\x0110=") {
+		t.Fatalf("expected a trailing CheckSum field, got %q", raw)
+	}
+}
+
+type fakeEnvelopeSource struct {
+	beginString string
+	msgType     string
+	sendingTime string
+	raw         string
+}
+
+func (f fakeEnvelopeSource) String() string      { return f.raw }
+func (f fakeEnvelopeSource) BeginString() string { return f.beginString }
+func (f fakeEnvelopeSource) MsgType() string     { return f.msgType }
+func (f fakeEnvelopeSource) SendingTime() string { return f.sendingTime }
+
+func TestToEnvelopePopulatesTypedFields(t *testing.T) {
+	src := fakeEnvelopeSource{
+		beginString: "FIX.4.2",
+		msgType:     "D",
+		sendingTime: "20260101-00:00:00",
+		raw:         "8=FIX.4.2\x01...\x01",
+	}
+
+	env := toEnvelope(src)
+
+	if env.BeginString != src.beginString || env.MsgType != src.msgType || env.SendingTime != src.sendingTime {
+		t.Fatalf("toEnvelope did not populate typed fields: %+v", env)
+	}
+	if string(env.Bytes) != src.raw {
+		t.Fatalf("toEnvelope Bytes = %q, want %q", env.Bytes, src.raw)
+	}
+}
+
+func TestToEnvelopePopulatesFieldMaps(t *testing.T) {
+	raw := fmt.Sprintf("%d=FIX.4.2\x01%d=D\x01%d=IBM\x01%d=123\x01",
+		tag.BeginString, tag.MsgType, tag.Symbol, tag.CheckSum)
+	src := fakeEnvelopeSource{beginString: "FIX.4.2", msgType: "D", raw: raw}
+
+	env := toEnvelope(src)
+
+	if got, want := string(env.HeaderFields[uint32(tag.BeginString)]), "FIX.4.2"; got != want {
+		t.Fatalf("HeaderFields[BeginString] = %q, want %q", got, want)
+	}
+	if got, want := string(env.BodyFields[uint32(tag.Symbol)]), "IBM"; got != want {
+		t.Fatalf("BodyFields[Symbol] = %q, want %q", got, want)
+	}
+	if got, want := string(env.TrailerFields[uint32(tag.CheckSum)]), "123"; got != want {
+		t.Fatalf("TrailerFields[CheckSum] = %q, want %q", got, want)
+	}
+}
+
+func TestSplitFIXFieldsRejectsMalformedInput(t *testing.T) {
+	if _, _, _, err := splitFIXFields([]byte("no-soh-here")); err == nil {
+		t.Fatal("expected an error for a field with no trailing SOH")
+	}
+}
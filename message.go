@@ -3,11 +3,37 @@ package quickfix
 import (
 	"bytes"
 	"fmt"
+	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/quickfixgo/quickfix/fix"
 	"github.com/quickfixgo/quickfix/fix/tag"
+	"github.com/quickfixgo/quickfix/trace"
 	"time"
 )
 
+//traceHeaderTag is the header tag quickfix reads a propagated SpanContext from, and
+//writes one back to on send. It defaults to a tag in the user-defined 10000+ range so it
+//never collides with a standard tag; Settings may override it with SetTraceHeaderTag.
+var traceHeaderTag fix.Tag = 10050
+
+//tracer is the active trace.Tracer used to instrument the FIX message lifecycle. It
+//defaults to a no-op tracer; Settings installs a real one via SetTracer when
+//TracingEnabled is configured.
+var tracer trace.Tracer = newNoopTracer()
+
+func newNoopTracer() trace.Tracer {
+	t, _ := trace.NewTracer(trace.Config{})
+	return t
+}
+
+//SetTracer installs the Tracer used to instrument the FIX message lifecycle.
+func SetTracer(t trace.Tracer) { tracer = t }
+
+//SetTraceHeaderTag overrides the header tag a propagated SpanContext is read from and
+//written to.
+func SetTraceHeaderTag(t fix.Tag) { traceHeaderTag = t }
+
+const traceCarrierKey = "x-fix-span-context"
+
 //Message is a FIX Message abstraction.
 type Message struct {
 	Header  FieldMap
@@ -25,6 +51,15 @@ type Message struct {
 
 	//field bytes as they appear in the raw message
 	fields []*fieldBytes
+
+	//SpanContext is the context of the fix.receive span started when this message was
+	//parsed (a child of any context carried in the header), and the parent of the fix.send
+	//span started when it is rebuilt. Nil when tracing is off.
+	SpanContext opentracing.SpanContext
+
+	//pooledBuf, when non-nil, is the buffer backing Bytes that Release returns to
+	//bufferPool. Messages parsed by parseMessage directly leave this nil.
+	pooledBuf *[]byte
 }
 
 //parseError is returned when bytes cannot be parsed as a FIX message.
@@ -34,39 +69,61 @@ type parseError struct {
 
 func (e parseError) Error() string { return fmt.Sprintf("error parsing message: %s", e.OrigError) }
 
-//parseMessage constructs a Message from a byte slice wrapping a FIX message.
+//parseMessage constructs a Message from a byte slice wrapping a FIX message. It is a
+//thin wrapper over ParseInto for callers that don't need to manage a Message's lifetime
+//through a pool; see MessageReader for the pooled, streaming equivalent.
 func parseMessage(rawMessage []byte) (*Message, error) {
-	var header, body, trailer fieldMap
-	header.init(headerFieldOrder)
-	body.init(normalFieldOrder)
-	trailer.init(trailerFieldOrder)
+	msg := new(Message)
+	err := ParseInto(msg, rawMessage)
+	return msg, err
+}
+
+//ParseInto parses rawMessage into msg, reusing msg's existing field slice and, when msg
+//was drawn from messagePool and already holds fieldMaps from a prior parse, its existing
+//fieldMaps too, instead of allocating new ones. msg is typically drawn from a pool (see
+//MessageReader); on success or failure it is always left populated, matching
+//parseMessage's contract of still returning a partial Message alongside a
+//length-mismatch error.
+func ParseInto(msg *Message, rawMessage []byte) error {
+	parseStart := time.Now()
 
-	msg := &Message{Header: header, Body: body, Trailer: trailer, Bytes: rawMessage}
+	header := resetFieldMap(msg.Header, headerFieldOrder)
+	body := resetFieldMap(msg.Body, normalFieldOrder)
+	trailer := resetFieldMap(msg.Trailer, trailerFieldOrder)
+
+	msg.Header, msg.Body, msg.Trailer = header, body, trailer
+	msg.Bytes = rawMessage
+	msg.bodyBytes = nil
+	msg.SpanContext = nil
 
 	//including required header and trailer fields, minimum of 7 fields can be expected
 	//TODO: expose size for priming
-	msg.fields = make([]*fieldBytes, 0, 7)
+	if cap(msg.fields) >= 7 {
+		msg.fields = msg.fields[:0]
+	} else {
+		msg.fields = make([]*fieldBytes, 0, 7)
+	}
 
 	var parsedFieldBytes *fieldBytes
 	var err error
 
 	//message must start with begin string, body length, msg type
 	if parsedFieldBytes, rawMessage, err = extractSpecificField(tag.BeginString, rawMessage); err != nil {
-		return nil, err
+		return err
 	}
 
 	msg.fields = append(msg.fields, parsedFieldBytes)
 	header.fieldLookup[parsedFieldBytes.Tag] = parsedFieldBytes
 
 	if parsedFieldBytes, rawMessage, err = extractSpecificField(tag.BodyLength, rawMessage); err != nil {
-		return nil, err
+		return err
 	}
 
 	msg.fields = append(msg.fields, parsedFieldBytes)
 	header.fieldLookup[parsedFieldBytes.Tag] = parsedFieldBytes
 
 	if parsedFieldBytes, rawMessage, err = extractSpecificField(tag.MsgType, rawMessage); err != nil {
-		return nil, err
+		return err
 	}
 
 	msg.fields = append(msg.fields, parsedFieldBytes)
@@ -77,7 +134,7 @@ func parseMessage(rawMessage []byte) (*Message, error) {
 	for {
 		parsedFieldBytes, rawMessage, err = extractField(rawMessage)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		msg.fields = append(msg.fields, parsedFieldBytes)
@@ -116,11 +173,67 @@ func parseMessage(rawMessage []byte) (*Message, error) {
 
 	bodyLength := new(fix.IntValue)
 	msg.Header.GetField(tag.BodyLength, bodyLength)
+
+	var finishSpan func()
+	msg.SpanContext, finishSpan = startReceiveSpan(header, parseStart)
+	defer finishSpan()
+
 	if bodyLength.Value != length {
-		return msg, parseError{OrigError: fmt.Sprintf("Incorrect Message Length, expected %d, got %d", bodyLength.Value, length)}
+		return parseError{OrigError: fmt.Sprintf("Incorrect Message Length, expected %d, got %d", bodyLength.Value, length)}
 	}
 
-	return msg, nil
+	return nil
+}
+
+//resetFieldMap reuses existing's underlying fieldMap in place - clearing its fieldLookup
+//map rather than reallocating one via init - when msg was drawn from messagePool and
+//existing already holds a fieldMap from a prior ParseInto call. fieldOrder is constant
+//per role (header/body/trailer), so the reused fieldMap's ordering state stays valid
+//across parses; only its field contents need clearing. A Message that has never been
+//parsed (existing is nil) still goes through init as before.
+func resetFieldMap(existing FieldMap, fieldOrder []fix.Tag) fieldMap {
+	if fm, ok := existing.(fieldMap); ok && fm.fieldLookup != nil {
+		for t := range fm.fieldLookup {
+			delete(fm.fieldLookup, t)
+		}
+		return fm
+	}
+
+	var fm fieldMap
+	fm.init(fieldOrder)
+	return fm
+}
+
+//startReceiveSpan starts a fix.receive span for the message whose header has just been
+//parsed, continuing the trace carried in the header's traceHeaderTag if one is present,
+//backdated to startTime (ParseInto's entry) so its duration covers the actual parse work
+//rather than reporting zero. It returns the span's SpanContext for propagation to a reply
+//via rebuild, and a finish func the caller must invoke once parsing completes - success or
+//failure - to close the span. It does nothing (and touches nothing in header) when
+//tracing is disabled, so the parse hot path pays only the cost of tracer.Enabled().
+func startReceiveSpan(header fieldMap, startTime time.Time) (opentracing.SpanContext, func()) {
+	if !tracer.Enabled() {
+		return nil, func() {}
+	}
+
+	carrier := trace.HeaderTagCarrier{}
+	encoded := new(fix.StringValue)
+	if header.GetField(traceHeaderTag, encoded) == nil && len(encoded.Value) != 0 {
+		carrier[traceCarrierKey] = encoded.Value
+	}
+
+	msgType := new(fix.StringValue)
+	header.GetField(tag.MsgType, msgType)
+	senderCompID := new(fix.StringValue)
+	header.GetField(tag.SenderCompID, senderCompID)
+	targetCompID := new(fix.StringValue)
+	header.GetField(tag.TargetCompID, targetCompID)
+	msgSeqNum := new(fix.IntValue)
+	header.GetField(tag.MsgSeqNum, msgSeqNum)
+
+	span := trace.StartReceiveSpan(tracer, carrier, msgType.Value, senderCompID.Value, targetCompID.Value, msgSeqNum.Value, startTime)
+
+	return span.Context(), span.Finish
 }
 
 //reverseRoute returns a message builder with routing header fields initialized as the reverse of this message.
@@ -188,14 +301,73 @@ func (m *Message) String() string {
 	return string(m.Bytes)
 }
 
+//BeginString returns the message's BeginString(8) header value.
+func (m *Message) BeginString() string {
+	f := new(fix.StringValue)
+	m.Header.GetField(tag.BeginString, f)
+	return f.Value
+}
+
+//MsgType returns the message's MsgType(35) header value.
+func (m *Message) MsgType() string {
+	f := new(fix.StringValue)
+	m.Header.GetField(tag.MsgType, f)
+	return f.Value
+}
+
+//SendingTime returns the message's SendingTime(52) header value.
+func (m *Message) SendingTime() string {
+	f := new(fix.StringValue)
+	m.Header.GetField(tag.SendingTime, f)
+	return f.Value
+}
+
+//Release returns m's pooled raw-message buffer and puts m itself back on messagePool for
+//reuse by a MessageReader. Call it once callers are done with a Message obtained from
+//MessageReader.ReadMessage. It is a true no-op for a Message parsed directly by
+//parseMessage - such a Message was never drawn from messagePool, so it is left
+//untouched rather than wiped out from under a caller still holding a reference to it.
+//
+//Header, Body and Trailer are deliberately left in place rather than nil'd out: they're
+//drawn from messagePool along with m, and resetFieldMap reuses their fieldLookup maps on
+//the next ParseInto instead of reallocating. Their stale *fieldBytes entries point into
+//the buffer just returned to bufferPool, but resetFieldMap clears them before the next
+//parse can observe that, and no caller is expected to read a Message after Release.
+func (m *Message) Release() {
+	if m.pooledBuf == nil {
+		return
+	}
+
+	*m.pooledBuf = (*m.pooledBuf)[:0]
+	bufferPool.Put(m.pooledBuf)
+	m.pooledBuf = nil
+
+	m.Bytes = nil
+	m.bodyBytes = nil
+	m.fields = m.fields[:0]
+	m.SpanContext = nil
+	m.ReceiveTime = time.Time{}
+
+	messagePool.Put(m)
+}
+
 func newCheckSum(value int) *fix.StringField {
 	return fix.NewStringField(tag.CheckSum, fmt.Sprintf("%03d", value))
 }
 
 func (m *Message) rebuild() {
+	rebuildStart := time.Now()
+
 	header := m.Header.(fieldMap)
 	trailer := m.Trailer.(fieldMap)
 
+	//injectSendSpan must run now, before bodyLength/checkSum are computed: it writes the
+	//propagated span context into header, which needs to be in place before header.length/
+	//total/write run below. The span itself stays open - via the returned finish func -
+	//until rebuild's serialization work actually completes.
+	finishSpan := injectSendSpan(header, m.SpanContext, rebuildStart)
+	defer finishSpan()
+
 	bodyLength := header.length() + len(m.bodyBytes) + trailer.length()
 	checkSum := header.total() + trailer.total()
 	for _, b := range m.bodyBytes {
@@ -213,3 +385,36 @@ func (m *Message) rebuild() {
 
 	m.Bytes = b.Bytes()
 }
+
+//injectSendSpan starts a fix.send span for an outbound message - as a child of parent
+//when it's a reply within an existing trace - tags it from header's routing fields, and
+//writes its context into header's traceHeaderTag before the checksum is computed. The
+//span is backdated to startTime (rebuild's entry); the caller must invoke the returned
+//finish func once rebuild's serialization work actually completes, so the span's
+//duration reflects that work rather than reporting zero. It is a no-op - returning a
+//no-op finish func - when tracing is disabled.
+func injectSendSpan(header fieldMap, parent opentracing.SpanContext, startTime time.Time) func() {
+	if !tracer.Enabled() {
+		return func() {}
+	}
+
+	msgType := new(fix.StringValue)
+	header.GetField(tag.MsgType, msgType)
+	senderCompID := new(fix.StringValue)
+	header.GetField(tag.SenderCompID, senderCompID)
+	targetCompID := new(fix.StringValue)
+	header.GetField(tag.TargetCompID, targetCompID)
+
+	span := trace.StartSendSpan(tracer, parent, msgType.Value, senderCompID.Value, targetCompID.Value, startTime)
+
+	carrier := trace.HeaderTagCarrier{}
+	if err := trace.InjectSendSpan(tracer, span, carrier); err != nil {
+		return span.Finish
+	}
+
+	if encoded, ok := carrier[traceCarrierKey]; ok {
+		header.Set(fix.NewStringField(traceHeaderTag, encoded))
+	}
+
+	return span.Finish
+}
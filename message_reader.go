@@ -0,0 +1,160 @@
+package quickfix
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/quickfixgo/quickfix/fix/tag"
+)
+
+//messagePool recycles *Message instances across ReadMessage calls so steady-state
+//streaming doesn't allocate a new Message per inbound FIX message.
+var messagePool = sync.Pool{
+	New: func() interface{} { return new(Message) },
+}
+
+//bufferPool recycles the byte buffers ReadMessage frames each raw message into.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, 4096)
+		return &buf
+	},
+}
+
+//MessageReader parses FIX messages off of an io.Reader, typically a socket, one at a
+//time. Each call to ReadMessage frames and parses a message in a single pass and hands
+//back a *Message backed by buffers drawn from a sync.Pool; call Message.Release once
+//done with it to return those buffers for reuse.
+type MessageReader struct {
+	r *bufio.Reader
+}
+
+//NewMessageReader wraps r for incremental, pooled parsing.
+func NewMessageReader(r io.Reader) *MessageReader {
+	return &MessageReader{r: bufio.NewReader(r)}
+}
+
+//ReadMessage reads and parses the next FIX message from the underlying reader.
+func (mr *MessageReader) ReadMessage() (*Message, error) {
+	bufPtr := bufferPool.Get().(*[]byte)
+
+	raw, err := mr.readFrame((*bufPtr)[:0])
+	if err != nil {
+		*bufPtr = (*bufPtr)[:0]
+		bufferPool.Put(bufPtr)
+		return nil, err
+	}
+	*bufPtr = raw
+
+	msg := messagePool.Get().(*Message)
+	msg.pooledBuf = bufPtr
+
+	if err := ParseInto(msg, raw); err != nil {
+		msg.Release()
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+//maxMessageSize bounds a single parsed FIX message's BodyLength, so a malformed or
+//malicious peer can't force readFrame into an unbounded (or negative-length) allocation.
+const maxMessageSize = 16 * 1024 * 1024 // 16MiB
+
+//maxHeaderFieldLength bounds a single SOH-delimited field read by readFieldInto outside
+//the span the declared BodyLength covers (BeginString, BodyLength itself, and CheckSum),
+//so a peer that never sends a SOH can't force buf to grow without bound.
+const maxHeaderFieldLength = 64
+
+//readFrame reads one complete raw FIX message - BeginString through CheckSum - into buf,
+//growing it as needed, by following the 8=.../9=len/.../10=xxx framing so the body can be
+//read directly into buf's tail in a single io.ReadFull rather than delimiter-scanned byte
+//by byte through an intermediate allocation.
+func (mr *MessageReader) readFrame(buf []byte) ([]byte, error) {
+	var err error
+
+	if buf, _, err = mr.readFieldInto(buf, maxHeaderFieldLength); err != nil { // BeginString
+		return nil, err
+	}
+
+	var lengthStart int
+	if buf, lengthStart, err = mr.readFieldInto(buf, maxHeaderFieldLength); err != nil { // BodyLength
+		return nil, err
+	}
+
+	bodyLength, err := parseBodyLength(buf[lengthStart:])
+	if err != nil {
+		return nil, err
+	}
+
+	bodyStart := len(buf)
+	bodyEnd := bodyStart + bodyLength
+	if cap(buf) < bodyEnd {
+		grown := make([]byte, len(buf), bodyEnd)
+		copy(grown, buf)
+		buf = grown
+	}
+	buf = buf[:bodyEnd]
+	if _, err := io.ReadFull(mr.r, buf[bodyStart:bodyEnd]); err != nil {
+		return nil, parseError{OrigError: "MessageReader: " + err.Error()}
+	}
+
+	if buf, _, err = mr.readFieldInto(buf, maxHeaderFieldLength); err != nil { // CheckSum
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+//readFieldInto reads bytes from the underlying reader up to and including the next SOH
+//delimiter, appending them directly to buf, and returns the offset within buf the field
+//started at so the caller can inspect it without a separate allocation. It rejects a
+//field longer than maxLen - without this, a peer that never sends a SOH could force buf
+//to grow without bound, since these fields lie outside the span the declared BodyLength
+//covers (before it is known, or after it) and it can't be used to cap the read.
+func (mr *MessageReader) readFieldInto(buf []byte, maxLen int) (out []byte, start int, err error) {
+	start = len(buf)
+	for {
+		if len(buf)-start >= maxLen {
+			return buf, start, parseError{OrigError: fmt.Sprintf("MessageReader: field exceeds %d bytes without a delimiter", maxLen)}
+		}
+
+		b, err := mr.r.ReadByte()
+		if err != nil {
+			return buf, start, parseError{OrigError: "MessageReader: " + err.Error()}
+		}
+
+		buf = append(buf, b)
+		if b == '\001' {
+			return buf, start, nil
+		}
+	}
+}
+
+//parseBodyLength extracts the numeric value out of a raw "9=<len>\001" field, rejecting
+//anything outside [0, maxMessageSize].
+func parseBodyLength(field []byte) (int, error) {
+	eq := bytes.IndexByte(field, '=')
+	if eq == -1 || field[len(field)-1] != '\001' {
+		return 0, parseError{OrigError: "MessageReader: malformed BodyLength field " + string(field)}
+	}
+
+	expectedTag := strconv.Itoa(int(tag.BodyLength))
+	if string(field[:eq]) != expectedTag {
+		return 0, parseError{OrigError: "MessageReader: Fields out of order, expected BodyLength, got " + string(field[:eq])}
+	}
+
+	bodyLength, err := strconv.Atoi(string(field[eq+1 : len(field)-1]))
+	if err != nil {
+		return 0, parseError{OrigError: "MessageReader: " + err.Error()}
+	}
+	if bodyLength < 0 || bodyLength > maxMessageSize {
+		return 0, parseError{OrigError: fmt.Sprintf("MessageReader: BodyLength %d out of range [0, %d]", bodyLength, maxMessageSize)}
+	}
+
+	return bodyLength, nil
+}
@@ -0,0 +1,53 @@
+package quickfix
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseBodyLengthRejectsNegative(t *testing.T) {
+	if _, err := parseBodyLength([]byte("9=-1\001")); err == nil {
+		t.Fatal("expected an error for a negative BodyLength")
+	}
+}
+
+func TestParseBodyLengthRejectsTooLarge(t *testing.T) {
+	if _, err := parseBodyLength([]byte("9=999999999\001")); err == nil {
+		t.Fatal("expected an error for a BodyLength over maxMessageSize")
+	}
+}
+
+func TestParseBodyLengthAcceptsValid(t *testing.T) {
+	n, err := parseBodyLength([]byte("9=42\001"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 42 {
+		t.Fatalf("got %d, want 42", n)
+	}
+}
+
+func TestMessageReaderReadFrameRejectsOversizedBodyLengthBeforeReadingBody(t *testing.T) {
+	mr := NewMessageReader(strings.NewReader("8=FIX.4.2\0019=999999999\001"))
+
+	if _, err := mr.readFrame(nil); err == nil {
+		t.Fatal("expected readFrame to reject an oversized BodyLength before reading the body")
+	}
+}
+
+func TestReadFieldIntoRejectsFieldWithoutDelimiter(t *testing.T) {
+	mr := NewMessageReader(strings.NewReader(strings.Repeat("x", maxHeaderFieldLength+1)))
+
+	if _, _, err := mr.readFieldInto(nil, maxHeaderFieldLength); err == nil {
+		t.Fatal("expected readFieldInto to reject a field with no SOH within maxLen bytes")
+	}
+}
+
+func TestReleaseIsNoopWithoutPooledBuf(t *testing.T) {
+	msg := &Message{Bytes: []byte("hello")}
+	msg.Release()
+
+	if string(msg.Bytes) != "hello" {
+		t.Fatalf("Release wiped Bytes on a Message with no pooledBuf: got %q", msg.Bytes)
+	}
+}
@@ -0,0 +1,40 @@
+package trace
+
+import (
+	opentracing "github.com/opentracing/opentracing-go"
+	jaeger "github.com/uber/jaeger-client-go"
+	jaegercfg "github.com/uber/jaeger-client-go/config"
+)
+
+//newJaegerTracer reports spans to a Jaeger agent over UDP at cfg.ConnectString.
+func newJaegerTracer(cfg Config) (Tracer, error) {
+	sender, err := jaeger.NewUDPTransport(cfg.ConnectString, 0)
+	if err != nil {
+		return nil, err
+	}
+	reporter := jaeger.NewRemoteReporter(sender)
+
+	jaegerCfg := jaegercfg.Configuration{
+		ServiceName: cfg.ServiceName,
+		Sampler: &jaegercfg.SamplerConfig{
+			Type:  jaeger.SamplerTypeProbabilistic,
+			Param: cfg.SamplerRate,
+		},
+	}
+
+	tracerImpl, closer, err := jaegerCfg.NewTracer(jaegercfg.Reporter(reporter))
+	if err != nil {
+		reporter.Close()
+		return nil, err
+	}
+
+	return &jaegerTracer{Tracer: tracerImpl, closer: closer}, nil
+}
+
+type jaegerTracer struct {
+	opentracing.Tracer
+	closer interface{ Close() error }
+}
+
+func (t *jaegerTracer) Enabled() bool { return true }
+func (t *jaegerTracer) Close() error  { return t.closer.Close() }
@@ -0,0 +1,70 @@
+package trace
+
+import (
+	"time"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+//HeaderTagCarrier adapts a single FIX header tag carrying a b3/jaeger-encoded context to
+//an opentracing.TextMapCarrier, so a SpanContext can be extracted from (or injected into)
+//it directly.
+type HeaderTagCarrier map[string]string
+
+func (c HeaderTagCarrier) ForeachKey(handler func(key, val string) error) error {
+	for k, v := range c {
+		if err := handler(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c HeaderTagCarrier) Set(key, val string) { c[key] = val }
+
+//StartReceiveSpan starts a fix.receive span for an inbound message, continuing the trace
+//carried in carrier when one is present. startTime backdates the span to when parsing
+//actually began, rather than when the header fields needed for its tags and carrier
+//extraction became available, so the span's duration reflects real parse latency instead
+//of reporting zero.
+func StartReceiveSpan(tracer opentracing.Tracer, carrier HeaderTagCarrier, msgType, senderCompID, targetCompID string, msgSeqNo int, startTime time.Time) opentracing.Span {
+	opts := []opentracing.StartSpanOption{opentracing.StartTime(startTime)}
+	if parent, err := tracer.Extract(opentracing.TextMap, carrier); err == nil {
+		opts = append(opts, opentracing.ChildOf(parent))
+	}
+
+	span := tracer.StartSpan("fix.receive", opts...)
+	ext.SpanKind.Set(span, ext.SpanKindConsumerEnum)
+	span.SetTag("fix.msg_type", msgType)
+	span.SetTag("fix.sender_comp_id", senderCompID)
+	span.SetTag("fix.target_comp_id", targetCompID)
+	span.SetTag("fix.msg_seq_num", msgSeqNo)
+
+	return span
+}
+
+//StartSendSpan starts a fix.send span for an outbound message, optionally as a child of
+//parent (e.g. the fix.receive span of the message being responded to). startTime
+//backdates the span to when rebuild began, so its duration covers the length/checksum
+//computation and serialization work rather than reporting zero.
+func StartSendSpan(tracer opentracing.Tracer, parent opentracing.SpanContext, msgType, senderCompID, targetCompID string, startTime time.Time) opentracing.Span {
+	opts := []opentracing.StartSpanOption{opentracing.StartTime(startTime)}
+	if parent != nil {
+		opts = append(opts, opentracing.ChildOf(parent))
+	}
+
+	span := tracer.StartSpan("fix.send", opts...)
+	ext.SpanKind.Set(span, ext.SpanKindProducerEnum)
+	span.SetTag("fix.msg_type", msgType)
+	span.SetTag("fix.sender_comp_id", senderCompID)
+	span.SetTag("fix.target_comp_id", targetCompID)
+
+	return span
+}
+
+//InjectSendSpan injects span's context into carrier so it travels with the outgoing
+//message's header.
+func InjectSendSpan(tracer opentracing.Tracer, span opentracing.Span, carrier HeaderTagCarrier) error {
+	return tracer.Inject(span.Context(), opentracing.TextMap, carrier)
+}
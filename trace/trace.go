@@ -0,0 +1,67 @@
+//Package trace wires OpenTracing spans around the FIX message lifecycle: a fix.receive
+//span for parsing an inbound message and a fix.send span for building an outbound one. It
+//has no spans for the FromApp/ToApp application callbacks or for resend handling.
+package trace
+
+import (
+	"fmt"
+
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+//CollectorType selects which tracing backend a Tracer reports spans to.
+type CollectorType string
+
+const (
+	CollectorNone   CollectorType = "none"
+	CollectorZipkin CollectorType = "zipkin"
+	CollectorJaeger CollectorType = "jaeger"
+)
+
+//Config controls span collection for a session. It mirrors the TracingEnabled,
+//CollectorType, ConnectString, SamplerRate, and ServiceName session settings.
+type Config struct {
+	Enabled       bool
+	CollectorType CollectorType
+	ConnectString string
+	SamplerRate   float64
+	ServiceName   string
+}
+
+//Tracer is the pluggable interface quickfix uses to start and propagate spans around the
+//FIX message lifecycle. It is an opentracing.Tracer with an added Close to release the
+//underlying collector connection on session shutdown.
+type Tracer interface {
+	opentracing.Tracer
+
+	//Enabled reports whether this Tracer actually records spans. Callers on the hot
+	//parse/send path check this before doing any span-related work, so a disabled
+	//Tracer costs nothing beyond this one check.
+	Enabled() bool
+
+	Close() error
+}
+
+//NewTracer builds the Tracer selected by cfg.CollectorType. A disabled or CollectorNone
+//config yields a no-op Tracer so instrumented code paths stay cheap when tracing is off.
+func NewTracer(cfg Config) (Tracer, error) {
+	if !cfg.Enabled || cfg.CollectorType == CollectorNone || cfg.CollectorType == "" {
+		return noopTracer{Tracer: opentracing.NoopTracer{}}, nil
+	}
+
+	switch cfg.CollectorType {
+	case CollectorZipkin:
+		return newZipkinTracer(cfg)
+	case CollectorJaeger:
+		return newJaegerTracer(cfg)
+	default:
+		return nil, fmt.Errorf("trace: unknown CollectorType %q", cfg.CollectorType)
+	}
+}
+
+type noopTracer struct {
+	opentracing.Tracer
+}
+
+func (noopTracer) Enabled() bool { return false }
+func (noopTracer) Close() error  { return nil }
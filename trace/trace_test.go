@@ -0,0 +1,35 @@
+package trace
+
+import "testing"
+
+func TestNewTracerDisabledIsNoop(t *testing.T) {
+	tracer, err := NewTracer(Config{Enabled: false})
+	if err != nil {
+		t.Fatalf("NewTracer returned error: %v", err)
+	}
+	if tracer.Enabled() {
+		t.Fatal("expected a disabled Config to yield a Tracer with Enabled() == false")
+	}
+}
+
+func TestNewTracerUnknownCollectorType(t *testing.T) {
+	if _, err := NewTracer(Config{Enabled: true, CollectorType: "carrier-pigeon"}); err == nil {
+		t.Fatal("expected an error for an unknown CollectorType")
+	}
+}
+
+func TestHeaderTagCarrierForeachKey(t *testing.T) {
+	carrier := HeaderTagCarrier{"a": "1", "b": "2"}
+
+	seen := map[string]string{}
+	err := carrier.ForeachKey(func(key, val string) error {
+		seen[key] = val
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForeachKey returned error: %v", err)
+	}
+	if len(seen) != 2 || seen["a"] != "1" || seen["b"] != "2" {
+		t.Fatalf("ForeachKey did not visit all entries, got %v", seen)
+	}
+}
@@ -0,0 +1,34 @@
+package trace
+
+import (
+	opentracing "github.com/opentracing/opentracing-go"
+	zipkin "github.com/openzipkin/zipkin-go-opentracing"
+)
+
+//newZipkinTracer reports spans to the Zipkin HTTP collector at cfg.ConnectString.
+func newZipkinTracer(cfg Config) (Tracer, error) {
+	collector, err := zipkin.NewHTTPCollector(cfg.ConnectString)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder := zipkin.NewRecorder(collector, false, cfg.ServiceName, cfg.ServiceName)
+	zipkinTracerImpl, err := zipkin.NewTracer(
+		recorder,
+		zipkin.WithSampler(zipkin.NewBoundarySampler(cfg.SamplerRate, 0)),
+	)
+	if err != nil {
+		collector.Close()
+		return nil, err
+	}
+
+	return &zipkinTracer{Tracer: zipkinTracerImpl, collector: collector}, nil
+}
+
+type zipkinTracer struct {
+	opentracing.Tracer
+	collector zipkin.Collector
+}
+
+func (t *zipkinTracer) Enabled() bool { return true }
+func (t *zipkinTracer) Close() error  { return t.collector.Close() }